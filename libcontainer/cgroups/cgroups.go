@@ -0,0 +1,40 @@
+package cgroups
+
+import "github.com/opencontainers/runc/libcontainer/configs"
+
+// Manager manages a container's cgroup(s) across its lifetime: applying a
+// pid to the cgroup, writing resource limits, reporting stats, and tearing
+// the cgroup down.
+type Manager interface {
+	// Apply creates a cgroup, if not yet created, and adds a process with
+	// the specified pid into that cgroup.
+	Apply(pid int) error
+
+	// GetPids returns the PIDs inside the cgroup.
+	GetPids() ([]int, error)
+
+	// GetAllPids returns the PIDs inside the cgroup and all its
+	// sub-cgroups.
+	GetAllPids() ([]int, error)
+
+	// GetStats returns statistics for the cgroup.
+	GetStats() (*Stats, error)
+
+	// Freeze sets the freezer cgroup to the specified state.
+	Freeze(state configs.FreezerState) error
+
+	// Destroy removes the cgroup.
+	Destroy() error
+
+	// GetPaths returns cgroup paths to save in a state file and to be
+	// used to restore the object later.
+	GetPaths() map[string]string
+
+	// Set sets the cgroup resources parameters for the entire config.
+	Set(container *configs.Config) error
+
+	// Update re-applies a subset of the cgroup resource limits to the
+	// already-created cgroup, leaving fields that are zero-valued in
+	// resources untouched and the freezer state as it was.
+	Update(resources *configs.Resources) error
+}
@@ -0,0 +1,234 @@
+//go:build linux
+// +build linux
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// controllerOrder lists the per-controller subsystems this manager knows
+// about, in the fixed order procsPath searches them for the path whose
+// cgroup.procs it reads. Apply and Destroy visit paths in map iteration
+// order.
+var controllerOrder = []string{"cpu", "cpuset", "memory", "blkio", "pids", "freezer"}
+
+// Manager is the cgroup v1 implementation of cgroups.Manager: one path per
+// controller, each controller applied to and read independently.
+type Manager struct {
+	paths map[string]string
+}
+
+// NewManager returns a v1 Manager for the given per-controller cgroup paths.
+func NewManager(paths map[string]string) *Manager {
+	return &Manager{paths: paths}
+}
+
+func (m *Manager) procsPath() string {
+	for _, name := range controllerOrder {
+		if p := m.paths[name]; p != "" {
+			return p
+		}
+	}
+	for _, p := range m.paths {
+		return p
+	}
+	return ""
+}
+
+func (m *Manager) Apply(pid int) error {
+	for _, path := range m.paths {
+		if path == "" {
+			continue
+		}
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return err
+		}
+		if err := writeFile(path, "cgroup.procs", strconv.Itoa(pid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) GetPids() ([]int, error) {
+	return parsePidsFile(m.procsPath(), "cgroup.procs")
+}
+
+func (m *Manager) GetAllPids() ([]int, error) {
+	root := m.procsPath()
+	if root == "" {
+		return nil, nil
+	}
+	seen := make(map[int]struct{})
+	var pids []int
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		found, err := parsePidsFile(path, "cgroup.procs")
+		if err != nil {
+			return err
+		}
+		for _, pid := range found {
+			if _, ok := seen[pid]; !ok {
+				seen[pid] = struct{}{}
+				pids = append(pids, pid)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pids, nil
+}
+
+func (m *Manager) GetStats() (*cgroups.Stats, error) {
+	stats := cgroups.NewStats()
+	if p := m.paths["memory"]; p != "" {
+		if v, err := readUint(p, "memory.usage_in_bytes"); err == nil {
+			stats.MemoryStats.Usage.Usage = v
+		}
+		if v, err := readUint(p, "memory.limit_in_bytes"); err == nil {
+			stats.MemoryStats.Usage.Limit = v
+		}
+	}
+	if p := m.paths["cpu"]; p != "" {
+		if v, err := readUint(p, "cpuacct.usage"); err == nil {
+			stats.CpuStats.CpuUsage.TotalUsage = v
+		}
+	}
+	if p := m.paths["pids"]; p != "" {
+		if v, err := readUint(p, "pids.current"); err == nil {
+			stats.PidsStats.Current = v
+		}
+	}
+	// PressureStats is left zero-valued: PSI is a cgroup v2 feature.
+	return stats, nil
+}
+
+func (m *Manager) Freeze(state configs.FreezerState) error {
+	p := m.paths["freezer"]
+	if p == "" || state == configs.Undefined {
+		return nil
+	}
+	var value string
+	switch state {
+	case configs.Frozen:
+		value = "FROZEN"
+	case configs.Thawed:
+		value = "THAWED"
+	}
+	return writeFile(p, "freezer.state", value)
+}
+
+func (m *Manager) Destroy() error {
+	for _, path := range m.paths {
+		if path == "" {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) GetPaths() map[string]string {
+	return m.paths
+}
+
+func (m *Manager) Set(container *configs.Config) error {
+	if container.Resources == nil {
+		return nil
+	}
+	return m.Update(container.Resources)
+}
+
+func (m *Manager) Update(r *configs.Resources) error {
+	if p := m.paths["cpu"]; p != "" {
+		if r.CpuShares != 0 {
+			if err := writeFile(p, "cpu.shares", strconv.FormatUint(r.CpuShares, 10)); err != nil {
+				return err
+			}
+		}
+		if r.CpuQuota != 0 {
+			if err := writeFile(p, "cpu.cfs_quota_us", strconv.FormatInt(r.CpuQuota, 10)); err != nil {
+				return err
+			}
+		}
+		if r.CpuPeriod != 0 {
+			if err := writeFile(p, "cpu.cfs_period_us", strconv.FormatUint(r.CpuPeriod, 10)); err != nil {
+				return err
+			}
+		}
+	}
+	if p := m.paths["cpuset"]; p != "" {
+		if r.CpusetCpus != "" {
+			if err := writeFile(p, "cpuset.cpus", r.CpusetCpus); err != nil {
+				return err
+			}
+		}
+		if r.CpusetMems != "" {
+			if err := writeFile(p, "cpuset.mems", r.CpusetMems); err != nil {
+				return err
+			}
+		}
+	}
+	if p := m.paths["memory"]; p != "" {
+		if r.Memory != 0 {
+			if err := writeFile(p, "memory.limit_in_bytes", strconv.FormatInt(r.Memory, 10)); err != nil {
+				return err
+			}
+		}
+		if r.MemoryReservation != 0 {
+			if err := writeFile(p, "memory.soft_limit_in_bytes", strconv.FormatInt(r.MemoryReservation, 10)); err != nil {
+				return err
+			}
+		}
+		if r.KernelMemory != 0 {
+			if err := writeFile(p, "memory.kmem.limit_in_bytes", strconv.FormatInt(r.KernelMemory, 10)); err != nil {
+				return err
+			}
+		}
+	}
+	if p := m.paths["blkio"]; p != "" && r.BlkioWeight != 0 {
+		if err := writeFile(p, "blkio.weight", strconv.FormatUint(uint64(r.BlkioWeight), 10)); err != nil {
+			return err
+		}
+	}
+	if p := m.paths["pids"]; p != "" && r.PidsLimit != 0 {
+		value := "max"
+		if r.PidsLimit > 0 {
+			value = strconv.FormatInt(r.PidsLimit, 10)
+		}
+		if err := writeFile(p, "pids.max", value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readUint(dir, file string) (uint64, error) {
+	data, err := readFile(dir, file)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(trimNewline(data), 10, 64)
+}
+
+func trimNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,45 @@
+//go:build linux
+// +build linux
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func writeFile(dir, file, data string) error {
+	if dir == "" {
+		return os.ErrNotExist
+	}
+	return os.WriteFile(filepath.Join(dir, file), []byte(data), 0o700)
+}
+
+func readFile(dir, file string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	return string(data), err
+}
+
+func parsePidsFile(dir, file string) ([]int, error) {
+	data, err := readFile(dir, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var pids []int
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		if line == "" {
+			continue
+		}
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, err
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
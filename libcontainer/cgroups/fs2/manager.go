@@ -0,0 +1,238 @@
+//go:build linux
+// +build linux
+
+package fs2
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// Manager is the cgroup v2 (unified hierarchy) implementation of
+// cgroups.Manager. Unlike the v1 Manager, there is a single path for the
+// whole container rather than one per controller.
+type Manager struct {
+	path string
+}
+
+// NewManager returns a v2 Manager rooted at path, e.g.
+// "/sys/fs/cgroup/myid".
+func NewManager(path string) *Manager {
+	return &Manager{path: path}
+}
+
+func (m *Manager) Apply(pid int) error {
+	if err := os.MkdirAll(m.path, 0o755); err != nil {
+		return err
+	}
+	return writeFile(m.path, "cgroup.procs", strconv.Itoa(pid))
+}
+
+func (m *Manager) GetPids() ([]int, error) {
+	return parsePids(m.path, "cgroup.procs")
+}
+
+// GetAllPids returns the PIDs in this cgroup and every cgroup nested below
+// it, since cgroup v2 enforces a strict single hierarchy and a container's
+// processes may have created their own sub-cgroups.
+func (m *Manager) GetAllPids() ([]int, error) {
+	root := m.path
+	seen := make(map[int]struct{})
+	var pids []int
+	err := walkCgroup(root, func(dir string) error {
+		found, err := parsePids(dir, "cgroup.procs")
+		if err != nil {
+			return err
+		}
+		for _, pid := range found {
+			if _, ok := seen[pid]; !ok {
+				seen[pid] = struct{}{}
+				pids = append(pids, pid)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pids, nil
+}
+
+func (m *Manager) GetStats() (*cgroups.Stats, error) {
+	stats := cgroups.NewStats()
+
+	if cpu, err := parseFlatKeyedFile(m.path, "cpu.stat"); err == nil {
+		stats.CpuStats.CpuUsage.TotalUsage = cpu["usage_usec"] * 1000
+	}
+	if mem, err := parseFlatKeyedFile(m.path, "memory.stat"); err == nil {
+		stats.MemoryStats.Stats = mem
+	}
+	if v, err := readUint(m.path, "memory.current"); err == nil {
+		stats.MemoryStats.Usage.Usage = v
+	}
+	if io, err := parseFlatKeyedFile(m.path, "io.stat"); err == nil {
+		if v, ok := io["rbytes"]; ok {
+			stats.BlkioStats.IoServiceBytesRecursive = append(stats.BlkioStats.IoServiceBytesRecursive,
+				cgroups.BlkioStatEntry{Op: "Read", Value: v})
+		}
+		if v, ok := io["wbytes"]; ok {
+			stats.BlkioStats.IoServiceBytesRecursive = append(stats.BlkioStats.IoServiceBytesRecursive,
+				cgroups.BlkioStatEntry{Op: "Write", Value: v})
+		}
+	}
+	if v, err := readUint(m.path, "pids.current"); err == nil {
+		stats.PidsStats.Current = v
+	}
+
+	// PSI is best-effort, like everything else above: a container on a
+	// kernel/config without it, or one we can't read it for, still has
+	// usable cpu/memory/io/pids stats worth returning.
+	if pressure, err := readPressureStats(m.path); err == nil {
+		stats.PressureStats = pressure
+	}
+
+	return stats, nil
+}
+
+func (m *Manager) Freeze(state configs.FreezerState) error {
+	if state == configs.Undefined {
+		return nil
+	}
+	value := "0"
+	if state == configs.Frozen {
+		value = "1"
+	}
+	return writeFile(m.path, "cgroup.freeze", value)
+}
+
+func (m *Manager) Destroy() error {
+	if err := os.Remove(m.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetPaths returns a single ""->path entry, matching the fact that cgroup
+// v2 has one path per container rather than one per controller.
+func (m *Manager) GetPaths() map[string]string {
+	return map[string]string{"": m.path}
+}
+
+func (m *Manager) Set(container *configs.Config) error {
+	if err := m.enableControllers(); err != nil {
+		return err
+	}
+	if container.Resources == nil {
+		return nil
+	}
+	return m.Update(container.Resources)
+}
+
+func (m *Manager) enableControllers() error {
+	return writeFile(m.path, "cgroup.subtree_control", "+cpu +cpuset +memory +io +pids")
+}
+
+func (m *Manager) Update(r *configs.Resources) error {
+	if r.CpuShares != 0 {
+		if err := writeFile(m.path, "cpu.weight", strconv.FormatUint(cpuSharesToWeight(r.CpuShares), 10)); err != nil {
+			return err
+		}
+	}
+	if r.CpuQuota != 0 || r.CpuPeriod != 0 {
+		quota, period := r.CpuQuota, r.CpuPeriod
+		if quota == 0 || period == 0 {
+			// Only one of quota/period was specified; fill in the other
+			// from the current cpu.max so we don't clobber it back to a
+			// default.
+			curQuota, curPeriod, err := m.readCpuMax()
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if quota == 0 {
+				quota = curQuota
+			}
+			if period == 0 {
+				period = curPeriod
+			}
+		}
+		if err := writeFile(m.path, "cpu.max", cpuQuotaPeriodToMax(quota, period)); err != nil {
+			return err
+		}
+	}
+	if r.CpusetCpus != "" {
+		if err := writeFile(m.path, "cpuset.cpus", r.CpusetCpus); err != nil {
+			return err
+		}
+	}
+	if r.CpusetMems != "" {
+		if err := writeFile(m.path, "cpuset.mems", r.CpusetMems); err != nil {
+			return err
+		}
+	}
+	if r.Memory != 0 {
+		if err := writeFile(m.path, "memory.max", strconv.FormatInt(r.Memory, 10)); err != nil {
+			return err
+		}
+	}
+	if r.MemoryReservation != 0 {
+		if err := writeFile(m.path, "memory.low", strconv.FormatInt(r.MemoryReservation, 10)); err != nil {
+			return err
+		}
+	}
+	if r.BlkioWeight != 0 {
+		if err := writeFile(m.path, "io.weight", strconv.FormatUint(blkioWeightToIOWeight(r.BlkioWeight), 10)); err != nil {
+			return err
+		}
+	}
+	if r.PidsLimit != 0 {
+		value := "max"
+		if r.PidsLimit > 0 {
+			value = strconv.FormatInt(r.PidsLimit, 10)
+		}
+		if err := writeFile(m.path, "pids.max", value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readCpuMax reads and parses the container's current cpu.max setting, so
+// a partial Update can preserve whichever of quota/period it left alone.
+func (m *Manager) readCpuMax() (quota int64, period uint64, err error) {
+	data, err := readFile(m.path, "cpu.max")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseCpuMax(strings.TrimSpace(data))
+}
+
+// walkCgroup calls fn for dir and every directory nested below it, without
+// descending into non-cgroup files.
+func walkCgroup(dir string, fn func(dir string) error) error {
+	if err := fn(dir); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if err := walkCgroup(dir+"/"+entry.Name(), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+//go:build linux
+// +build linux
+
+package fs2
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestManagerGetPaths(t *testing.T) {
+	m := NewManager("/sys/fs/cgroup/myid")
+	paths := m.GetPaths()
+	if len(paths) != 1 {
+		t.Fatalf("expected a single path entry, got %d", len(paths))
+	}
+	if paths[""] != "/sys/fs/cgroup/myid" {
+		t.Fatalf("expected paths[\"\"] to be the container path, got %q", paths[""])
+	}
+}
+
+func TestManagerGetAllPids(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "child")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "cgroup.procs"), []byte("1\n2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "cgroup.procs"), []byte("3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(root)
+	pids, err := m.GetAllPids()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Ints(pids)
+	if len(pids) != 3 || pids[0] != 1 || pids[1] != 2 || pids[2] != 3 {
+		t.Fatalf("expected pids [1 2 3], got %v", pids)
+	}
+}
+
+// TestManagerGetStatsToleratesUnreadablePressure makes sure a PSI read
+// failure (e.g. a permission error) doesn't discard the cpu/memory/io/pids
+// stats GetStats already gathered successfully, since PSI is best-effort.
+func TestManagerGetStatsToleratesUnreadablePressure(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "memory.current"), []byte("4096\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A directory in place of cpu.pressure makes reading it fail with
+	// something other than "not exist", without needing root to set up a
+	// real permission-denied file.
+	if err := os.MkdirAll(filepath.Join(root, "cpu.pressure"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(root)
+	stats, err := m.GetStats()
+	if err != nil {
+		t.Fatalf("expected an unreadable pressure file to not fail GetStats, got %v", err)
+	}
+	if stats.MemoryStats.Usage.Usage != 4096 {
+		t.Fatalf("expected memory usage 4096 to still be reported, got %d", stats.MemoryStats.Usage.Usage)
+	}
+	if stats.PressureStats.CPU.Some.Total != 0 {
+		t.Fatalf("expected zero-valued PSI stats when unreadable, got %+v", stats.PressureStats.CPU)
+	}
+}
+
+// TestManagerUpdateCpuMaxPreservesUntouchedField makes sure a partial cpu
+// update doesn't clobber whichever half of cpu.max (quota vs. period) the
+// caller didn't mention, since both share a single file.
+func TestManagerUpdateCpuMaxPreservesUntouchedField(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "cpu.max"), []byte("50000 100000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(root)
+	if err := m.Update(&configs.Resources{CpuPeriod: 200000}); err != nil {
+		t.Fatal(err)
+	}
+	quota, period, err := m.readCpuMax()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quota != 50000 {
+		t.Fatalf("expected untouched quota 50000, got %d", quota)
+	}
+	if period != 200000 {
+		t.Fatalf("expected updated period 200000, got %d", period)
+	}
+
+	if err := m.Update(&configs.Resources{CpuQuota: 5000}); err != nil {
+		t.Fatal(err)
+	}
+	quota, period, err = m.readCpuMax()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quota != 5000 {
+		t.Fatalf("expected updated quota 5000, got %d", quota)
+	}
+	if period != 200000 {
+		t.Fatalf("expected untouched period 200000, got %d", period)
+	}
+}
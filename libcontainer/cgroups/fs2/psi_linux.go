@@ -0,0 +1,89 @@
+//go:build linux
+// +build linux
+
+package fs2
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// readPSI reads a single cgroup v2 pressure file (cpu.pressure,
+// memory.pressure or io.pressure), tolerating the two-line "some ..." /
+// "full ..." format and ignoring any keys it doesn't recognize. A missing
+// file (PSI disabled, or not supported by the running kernel) is not an
+// error: it just leaves the returned stats zero-valued.
+func readPSI(path string) (cgroups.PSIStats, error) {
+	var stats cgroups.PSIStats
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stats, nil
+		}
+		return stats, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var data *cgroups.PSIData
+		switch fields[0] {
+		case "some":
+			data = &stats.Some
+		case "full":
+			data = &stats.Full
+		default:
+			continue
+		}
+		for _, kv := range fields[1:] {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "avg10":
+				data.Avg10, _ = strconv.ParseFloat(value, 64)
+			case "avg60":
+				data.Avg60, _ = strconv.ParseFloat(value, 64)
+			case "avg300":
+				data.Avg300, _ = strconv.ParseFloat(value, 64)
+			case "total":
+				data.Total, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+// readPressureStats populates a PressureStats by reading cpu.pressure,
+// memory.pressure and io.pressure out of the unified cgroup at dir.
+func readPressureStats(dir string) (cgroups.PressureStats, error) {
+	var (
+		p   cgroups.PressureStats
+		err error
+	)
+	if p.CPU, err = readPSI(filepath.Join(dir, "cpu.pressure")); err != nil {
+		return p, err
+	}
+	if p.Memory, err = readPSI(filepath.Join(dir, "memory.pressure")); err != nil {
+		return p, err
+	}
+	if p.IO, err = readPSI(filepath.Join(dir, "io.pressure")); err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -0,0 +1,59 @@
+//go:build linux
+// +build linux
+
+package fs2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadPSI(t *testing.T) {
+	dir := t.TempDir()
+	data := "some avg10=0.10 avg60=0.20 avg300=0.30 total=1000\nfull avg10=0.01 avg60=0.02 avg300=0.03 total=500\n"
+	if err := os.WriteFile(filepath.Join(dir, "cpu.pressure"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := readPSI(filepath.Join(dir, "cpu.pressure"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Some.Avg10 != 0.10 || stats.Some.Avg60 != 0.20 || stats.Some.Avg300 != 0.30 || stats.Some.Total != 1000 {
+		t.Fatalf("unexpected some stats: %+v", stats.Some)
+	}
+	if stats.Full.Avg10 != 0.01 || stats.Full.Avg60 != 0.02 || stats.Full.Avg300 != 0.03 || stats.Full.Total != 500 {
+		t.Fatalf("unexpected full stats: %+v", stats.Full)
+	}
+}
+
+func TestReadPSIMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	stats, err := readPSI(filepath.Join(dir, "cpu.pressure"))
+	if err != nil {
+		t.Fatalf("expected a missing PSI file to not be an error, got %v", err)
+	}
+	if stats.Some.Total != 0 || stats.Full.Total != 0 {
+		t.Fatalf("expected zero-valued stats for a missing file, got %+v", stats)
+	}
+}
+
+func TestReadPressureStats(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "memory.pressure"), []byte("some avg10=1.5 avg60=0 avg300=0 total=42\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// cpu.pressure and io.pressure are intentionally left missing.
+
+	p, err := readPressureStats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Memory.Some.Avg10 != 1.5 || p.Memory.Some.Total != 42 {
+		t.Fatalf("unexpected memory pressure: %+v", p.Memory)
+	}
+	if p.CPU.Some.Total != 0 || p.IO.Some.Total != 0 {
+		t.Fatalf("expected zero pressure for missing files, got cpu=%+v io=%+v", p.CPU, p.IO)
+	}
+}
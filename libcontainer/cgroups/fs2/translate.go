@@ -0,0 +1,67 @@
+package fs2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultCpuPeriod is the period, in microseconds, assumed for cpu.max when
+// the caller didn't specify one.
+const defaultCpuPeriod = 100000
+
+// cpuSharesToWeight converts a v1-style cpu.shares value (range [2,
+// 262144]) into the equivalent cgroup v2 cpu.weight value (range [1,
+// 10000]), using the same linear mapping the kernel documents for
+// cgroup2.
+func cpuSharesToWeight(shares uint64) uint64 {
+	if shares == 0 {
+		return 0
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+// blkioWeightToIOWeight converts a v1-style blkio.weight value (range [10,
+// 1000]) into the equivalent cgroup v2 io.weight value (range [1, 10000]).
+func blkioWeightToIOWeight(weight uint16) uint64 {
+	if weight == 0 {
+		return 0
+	}
+	return 1 + (uint64(weight)-10)*9999/990
+}
+
+// cpuQuotaPeriodToMax renders a v1-style quota/period pair as a cgroup v2
+// cpu.max value ("max" or "<quota> <period>").
+func cpuQuotaPeriodToMax(quota int64, period uint64) string {
+	if period == 0 {
+		period = defaultCpuPeriod
+	}
+	if quota <= 0 {
+		return fmt.Sprintf("max %d", period)
+	}
+	return fmt.Sprintf("%d %d", quota, period)
+}
+
+// parseCpuMax parses a cgroup v2 cpu.max value ("max <period>" or "<quota>
+// <period>") back into a v1-style quota/period pair, with quota <= 0
+// meaning "max" (unlimited). It is the inverse of cpuQuotaPeriodToMax, used
+// to recover the half of a previous setting that a partial update doesn't
+// touch.
+func parseCpuMax(value string) (quota int64, period uint64, err error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid cpu.max value %q", value)
+	}
+	if fields[0] == "max" {
+		quota = 0
+	} else {
+		quota, err = strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid cpu.max quota %q: %w", fields[0], err)
+		}
+	}
+	if period, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid cpu.max period %q: %w", fields[1], err)
+	}
+	return quota, period, nil
+}
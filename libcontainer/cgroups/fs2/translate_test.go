@@ -0,0 +1,76 @@
+package fs2
+
+import "testing"
+
+func TestCpuSharesToWeight(t *testing.T) {
+	cases := map[uint64]uint64{
+		0:      0,
+		2:      1,
+		262144: 10000,
+	}
+	for shares, expected := range cases {
+		if got := cpuSharesToWeight(shares); got != expected {
+			t.Errorf("cpuSharesToWeight(%d) = %d, want %d", shares, got, expected)
+		}
+	}
+}
+
+func TestBlkioWeightToIOWeight(t *testing.T) {
+	cases := map[uint16]uint64{
+		0:    0,
+		10:   1,
+		1000: 10000,
+	}
+	for weight, expected := range cases {
+		if got := blkioWeightToIOWeight(weight); got != expected {
+			t.Errorf("blkioWeightToIOWeight(%d) = %d, want %d", weight, got, expected)
+		}
+	}
+}
+
+func TestParseCpuMax(t *testing.T) {
+	cases := []struct {
+		value     string
+		quota     int64
+		period    uint64
+		expectErr bool
+	}{
+		{"max 100000", 0, 100000, false},
+		{"50000 100000", 50000, 100000, false},
+		{"bogus", 0, 0, true},
+		{"50000 bogus", 0, 0, true},
+	}
+	for _, c := range cases {
+		quota, period, err := parseCpuMax(c.value)
+		if c.expectErr {
+			if err == nil {
+				t.Errorf("parseCpuMax(%q): expected an error, got none", c.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCpuMax(%q): unexpected error: %v", c.value, err)
+			continue
+		}
+		if quota != c.quota || period != c.period {
+			t.Errorf("parseCpuMax(%q) = (%d, %d), want (%d, %d)", c.value, quota, period, c.quota, c.period)
+		}
+	}
+}
+
+func TestCpuQuotaPeriodToMax(t *testing.T) {
+	cases := []struct {
+		quota, period int64
+		expected      string
+	}{
+		{0, 0, "max 100000"},
+		{-1, 0, "max 100000"},
+		{50000, 100000, "50000 100000"},
+		{50000, 0, "50000 100000"},
+	}
+	for _, c := range cases {
+		if got := cpuQuotaPeriodToMax(c.quota, uint64(c.period)); got != c.expected {
+			t.Errorf("cpuQuotaPeriodToMax(%d, %d) = %q, want %q", c.quota, c.period, got, c.expected)
+		}
+	}
+}
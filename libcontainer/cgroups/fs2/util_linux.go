@@ -0,0 +1,89 @@
+//go:build linux
+// +build linux
+
+package fs2
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func writeFile(dir, file, data string) error {
+	return os.WriteFile(filepath.Join(dir, file), []byte(data), 0o700)
+}
+
+func readFile(dir, file string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	return string(data), err
+}
+
+func readUint(dir, file string) (uint64, error) {
+	data, err := readFile(dir, file)
+	if err != nil {
+		return 0, err
+	}
+	data = strings.TrimSpace(data)
+	if data == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(data, 10, 64)
+}
+
+// parseFlatKeyedFile parses the "key value\n..." format used by cpu.stat,
+// memory.stat and io.stat (io.stat is keyed per-device; this collapses it
+// into the same flat map, summing across devices).
+func parseFlatKeyedFile(dir, file string) (map[string]uint64, error) {
+	data, err := readFile(dir, file)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]uint64)
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && !strings.Contains(fields[1], "=") {
+			if n, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+				out[fields[0]] += n
+			}
+			continue
+		}
+		for _, f := range fields {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			out[key] += n
+		}
+	}
+	return out, nil
+}
+
+func parsePids(dir, file string) ([]int, error) {
+	data, err := readFile(dir, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var pids []int
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		if line == "" {
+			continue
+		}
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, err
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
@@ -0,0 +1,59 @@
+// Package manager picks between the cgroup v1 and v2 implementations of
+// cgroups.Manager based on how /sys/fs/cgroup is mounted, so callers don't
+// have to care which hierarchy the host is running.
+package manager
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs2"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+const cgroupMountDir = "/sys/fs/cgroup"
+
+// New returns a v2 Manager if /sys/fs/cgroup is mounted as a unified
+// cgroup2 hierarchy, or a v1 Manager otherwise. paths is the per-controller
+// path map used by the v1 manager; for v2, the first non-empty path in it
+// (conventionally paths[""]) is used as the container's single cgroup path.
+//
+// This lives in its own subpackage, rather than as cgroups.NewManager,
+// because picking between fs and fs2 means importing both, and both
+// already import cgroups for the Manager interface; putting the picker in
+// cgroups itself would create an import cycle.
+func New(config *configs.Config, paths map[string]string) (cgroups.Manager, error) {
+	isV2, err := isCgroup2UnifiedMode()
+	if err != nil {
+		return nil, err
+	}
+	return newManager(isV2, config, paths), nil
+}
+
+// newManager applies the v1-vs-v2 decision once isV2 is known, kept
+// separate from New so the path-selection logic can be tested without
+// touching the filesystem via unix.Statfs.
+func newManager(isV2 bool, config *configs.Config, paths map[string]string) cgroups.Manager {
+	if isV2 {
+		path := paths[""]
+		if path == "" {
+			for _, p := range paths {
+				if p != "" {
+					path = p
+					break
+				}
+			}
+		}
+		return fs2.NewManager(path)
+	}
+	return fs.NewManager(paths)
+}
+
+func isCgroup2UnifiedMode() (bool, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(cgroupMountDir, &st); err != nil {
+		return false, err
+	}
+	return st.Type == unix.CGROUP2_SUPER_MAGIC, nil
+}
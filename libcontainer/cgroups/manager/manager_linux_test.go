@@ -0,0 +1,37 @@
+//go:build linux
+// +build linux
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs2"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestNewManagerPicksV1(t *testing.T) {
+	m := newManager(false, &configs.Config{}, map[string]string{"memory": "/sys/fs/cgroup/memory/myid"})
+	if _, ok := m.(*fs.Manager); !ok {
+		t.Fatalf("expected a v1 *fs.Manager, got %T", m)
+	}
+}
+
+func TestNewManagerPicksV2(t *testing.T) {
+	m := newManager(true, &configs.Config{}, map[string]string{"": "/sys/fs/cgroup/myid"})
+	if _, ok := m.(*fs2.Manager); !ok {
+		t.Fatalf("expected a v2 *fs2.Manager, got %T", m)
+	}
+}
+
+func TestNewManagerV2FallsBackToAnyPath(t *testing.T) {
+	m := newManager(true, &configs.Config{}, map[string]string{"memory": "/sys/fs/cgroup/myid"})
+	v2, ok := m.(*fs2.Manager)
+	if !ok {
+		t.Fatalf("expected a v2 *fs2.Manager, got %T", m)
+	}
+	if paths := v2.GetPaths(); paths[""] != "/sys/fs/cgroup/myid" {
+		t.Fatalf("expected the only path given to be used for v2, got %v", paths)
+	}
+}
@@ -0,0 +1,90 @@
+package cgroups
+
+type MemoryData struct {
+	Usage    uint64 `json:"usage,omitempty"`
+	MaxUsage uint64 `json:"max_usage,omitempty"`
+	Failcnt  uint64 `json:"failcnt"`
+	Limit    uint64 `json:"limit"`
+}
+
+type MemoryStats struct {
+	// Usage of memory.
+	Usage MemoryData `json:"usage,omitempty"`
+	// Usage of memory + swap.
+	SwapUsage MemoryData `json:"swap_usage,omitempty"`
+	// Usage of kernel memory.
+	KernelUsage MemoryData        `json:"kernel_usage,omitempty"`
+	Stats       map[string]uint64 `json:"stats,omitempty"`
+}
+
+type CpuUsage struct {
+	TotalUsage        uint64   `json:"total_usage,omitempty"`
+	PercpuUsage       []uint64 `json:"percpu_usage,omitempty"`
+	UsageInKernelmode uint64   `json:"usage_in_kernelmode"`
+	UsageInUsermode   uint64   `json:"usage_in_usermode"`
+}
+
+type CpuStats struct {
+	CpuUsage CpuUsage `json:"cpu_usage,omitempty"`
+}
+
+type BlkioStatEntry struct {
+	Major uint64 `json:"major,omitempty"`
+	Minor uint64 `json:"minor,omitempty"`
+	Op    string `json:"op,omitempty"`
+	Value uint64 `json:"value,omitempty"`
+}
+
+type BlkioStats struct {
+	IoServiceBytesRecursive []BlkioStatEntry `json:"io_service_bytes_recursive,omitempty"`
+}
+
+type PidsStats struct {
+	// Current is the number of pids in the cgroup.
+	Current uint64 `json:"current,omitempty"`
+	// Limit is the hard limit on the number of pids in the cgroup, or 0 if
+	// unlimited.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// PSIData holds one line ("some" or "full") of a cgroup v2 */.pressure file.
+type PSIData struct {
+	// Avg10 is the average percentage of time stalled over the last 10s.
+	Avg10 float64 `json:"avg10"`
+	// Avg60 is the average percentage of time stalled over the last 60s.
+	Avg60 float64 `json:"avg60"`
+	// Avg300 is the average percentage of time stalled over the last 300s.
+	Avg300 float64 `json:"avg300"`
+	// Total is the total stall time, in microseconds.
+	Total uint64 `json:"total"`
+}
+
+// PSIStats holds the "some" and "full" lines of a single cgroup v2
+// */.pressure file.
+type PSIStats struct {
+	Some PSIData `json:"some,omitempty"`
+	Full PSIData `json:"full,omitempty"`
+}
+
+// PressureStats holds the pressure stall information (PSI) for a cgroup, as
+// read from cpu.pressure, memory.pressure and io.pressure. It is only
+// populated on cgroup v2; it is left zero-valued on v1.
+type PressureStats struct {
+	CPU    PSIStats `json:"cpu,omitempty"`
+	Memory PSIStats `json:"memory,omitempty"`
+	IO     PSIStats `json:"io,omitempty"`
+}
+
+// Stats holds the stats read from a container's cgroup.
+type Stats struct {
+	CpuStats      CpuStats      `json:"cpu_stats,omitempty"`
+	MemoryStats   MemoryStats   `json:"memory_stats,omitempty"`
+	BlkioStats    BlkioStats    `json:"blkio_stats,omitempty"`
+	PidsStats     PidsStats     `json:"pids_stats,omitempty"`
+	PressureStats PressureStats `json:"pressure_stats,omitempty"`
+}
+
+func NewStats() *Stats {
+	memoryStats := MemoryStats{Stats: make(map[string]uint64)}
+	return &Stats{MemoryStats: memoryStats}
+}
@@ -0,0 +1,134 @@
+package configs
+
+import "time"
+
+// NamespaceType is the type of a linux namespace.
+type NamespaceType string
+
+const (
+	NEWNET  NamespaceType = "NEWNET"
+	NEWPID  NamespaceType = "NEWPID"
+	NEWNS   NamespaceType = "NEWNS"
+	NEWUTS  NamespaceType = "NEWUTS"
+	NEWIPC  NamespaceType = "NEWIPC"
+	NEWUSER NamespaceType = "NEWUSER"
+)
+
+// Namespace defines configuration for a single linux namespace, optionally
+// bound to an existing namespace on the host via Path.
+type Namespace struct {
+	Type NamespaceType
+	Path string
+}
+
+// Namespaces is a list of linux namespaces to be attached to a container.
+type Namespaces []Namespace
+
+var namespaceFiles = map[NamespaceType]string{
+	NEWNET:  "net",
+	NEWNS:   "mnt",
+	NEWPID:  "pid",
+	NEWIPC:  "ipc",
+	NEWUSER: "user",
+	NEWUTS:  "uts",
+}
+
+// File returns the name of the /proc/<pid>/ns/<file> entry corresponding to
+// this namespace type.
+func (n NamespaceType) File() string {
+	return namespaceFiles[n]
+}
+
+// FreezerState is the state of the freezer cgroup subsystem.
+type FreezerState string
+
+const (
+	Undefined FreezerState = ""
+	Frozen    FreezerState = "FROZEN"
+	Thawed    FreezerState = "THAWED"
+)
+
+// Resources represents the set of cgroup resource limits that can be applied
+// to a container, either at create time or via a later update.
+type Resources struct {
+	// CPU shares (relative weight vs. other containers).
+	CpuShares uint64
+	// CPU hardcap limit in microseconds, within a given CpuPeriod.
+	CpuQuota int64
+	// CPU period to be used for hardcapping.
+	CpuPeriod uint64
+
+	// CPUs to use within the cpuset cgroup.
+	CpusetCpus string
+	// Memory nodes to use within the cpuset cgroup.
+	CpusetMems string
+
+	// Memory limit in bytes.
+	Memory int64
+	// Memory reservation (soft limit) in bytes.
+	MemoryReservation int64
+	// Kernel memory limit in bytes.
+	KernelMemory int64
+
+	// Blkio weight of the container, in the range [10, 1000].
+	BlkioWeight uint16
+
+	// PidsLimit is the maximum number of PIDs allowed, or -1 for unlimited.
+	PidsLimit int64
+}
+
+// ApplyUpdate merges delta into r, field by field, treating a zero-valued
+// field in delta (0, "", or the fixed-zero equivalent) as "leave untouched"
+// rather than "set to zero". It is used to apply a partial resources update
+// to a running container without clobbering controllers the caller didn't
+// mention.
+func (r *Resources) ApplyUpdate(delta *Resources) {
+	if delta.CpuShares != 0 {
+		r.CpuShares = delta.CpuShares
+	}
+	if delta.CpuQuota != 0 {
+		r.CpuQuota = delta.CpuQuota
+	}
+	if delta.CpuPeriod != 0 {
+		r.CpuPeriod = delta.CpuPeriod
+	}
+	if delta.CpusetCpus != "" {
+		r.CpusetCpus = delta.CpusetCpus
+	}
+	if delta.CpusetMems != "" {
+		r.CpusetMems = delta.CpusetMems
+	}
+	if delta.Memory != 0 {
+		r.Memory = delta.Memory
+	}
+	if delta.MemoryReservation != 0 {
+		r.MemoryReservation = delta.MemoryReservation
+	}
+	if delta.KernelMemory != 0 {
+		r.KernelMemory = delta.KernelMemory
+	}
+	if delta.BlkioWeight != 0 {
+		r.BlkioWeight = delta.BlkioWeight
+	}
+	if delta.PidsLimit != 0 {
+		r.PidsLimit = delta.PidsLimit
+	}
+}
+
+// Config defines the configuration options for generating a container's
+// root filesystem and running processes inside it.
+type Config struct {
+	// Namespaces specifies the container's namespaces that it should setup
+	// when cloning the init process. If a namespace is not provided that
+	// namespace is shared from the container's parent process.
+	Namespaces Namespaces
+
+	// Resources contains the various cgroups settings to apply to this
+	// container's first process.
+	Resources *Resources
+
+	// ProcessWatchInterval is the polling interval Container.WatchProcesses
+	// uses to detect cgroup membership changes. Zero means the container
+	// picks its own default.
+	ProcessWatchInterval time.Duration
+}
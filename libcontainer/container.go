@@ -0,0 +1,118 @@
+package libcontainer
+
+import (
+	"context"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// Status is the status of a container.
+type Status int
+
+const (
+	// Created is the status that denotes the container exists but has not
+	// been run yet.
+	Created Status = iota
+
+	// Running is the status that denotes the container exists and is
+	// running.
+	Running
+
+	// Pausing is the status that denotes the container exists, it is in
+	// the process of being paused.
+	Pausing
+
+	// Paused is the status that denotes the container exists, but all its
+	// processes are paused.
+	Paused
+
+	// Stopped is the status that denotes the container does not have any
+	// processes running.
+	Stopped
+)
+
+// BaseState represents the platform agnostic pieces relating to a running
+// container's state.
+type BaseState struct {
+	// ID is the container ID.
+	ID string `json:"id"`
+
+	// InitProcessPid is the init process id in the parent namespace.
+	InitProcessPid int `json:"init_process_pid"`
+
+	// InitProcessStartTime is the init process start time in clock cycles
+	// since boot time.
+	InitProcessStartTime string `json:"init_process_start"`
+
+	// Config is the container's configuration.
+	Config configs.Config `json:"config"`
+}
+
+// State represents a running container's state.
+type State struct {
+	BaseState
+
+	// CgroupPaths contain the paths to all the container's cgroups, as
+	// returned by (cgroups.Manager).GetPaths.
+	CgroupPaths map[string]string `json:"cgroup_paths"`
+
+	// NamespacePaths are filepaths to the container's namespaces, keyed by
+	// the namespace type.
+	NamespacePaths map[configs.NamespaceType]string `json:"namespace_paths"`
+
+	// ExternalDescriptors are extra file descriptors that are inherited by
+	// the init process, kept here so they survive a checkpoint/restore.
+	ExternalDescriptors []string `json:"external_descriptors,omitempty"`
+
+	// AllPids is the recursive set of PIDs in the container's cgroup and
+	// all of its child cgroups, as returned by
+	// (cgroups.Manager).GetAllPids.
+	AllPids []int `json:"all_pids,omitempty"`
+}
+
+// Stats holds runtime stats for a container.
+type Stats struct {
+	CgroupStats *cgroups.Stats `json:"cgroup_stats,omitempty"`
+}
+
+// Container is the interface implemented by a libcontainer container, the
+// in-process handle used to manage the lifecycle of a single container.
+type Container interface {
+	// ID returns the container's unique ID.
+	ID() string
+
+	// Config returns the initial configuration the container was created
+	// with.
+	Config() configs.Config
+
+	// Status returns the current status of the container.
+	Status() (Status, error)
+
+	// State returns the current container's state information.
+	State() (*State, error)
+
+	// Processes returns the PIDs of all processes belonging to the
+	// container's top cgroup, without recursing into sub-cgroups.
+	Processes() ([]int, error)
+
+	// ProcessesRecursive returns the PIDs of all processes belonging to
+	// the container's cgroup and all of its child cgroups.
+	ProcessesRecursive() ([]int, error)
+
+	// WatchProcesses streams ProcessEvents for pids joining or leaving the
+	// container's cgroups until ctx is cancelled.
+	WatchProcesses(ctx context.Context) <-chan ProcessEvent
+
+	// Stats returns statistics for the container.
+	Stats() (*Stats, error)
+
+	// Set updates the container's resource configuration and applies it
+	// in-place to the container's cgroups.
+	Set(config configs.Config) error
+
+	// Update re-applies a subset of the container's cgroup resource
+	// limits to the running container, leaving any fields left
+	// zero-valued in resources untouched.
+	Update(resources *configs.Resources) error
+}
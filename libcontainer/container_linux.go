@@ -0,0 +1,260 @@
+//go:build linux
+// +build linux
+
+package libcontainer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// defaultProcessWatchInterval is how often WatchProcesses polls the
+// container's cgroup for membership changes when the container was not
+// configured with an explicit interval.
+const defaultProcessWatchInterval = 1 * time.Second
+
+// linuxContainer is the Linux-specific implementation of Container.
+type linuxContainer struct {
+	id            string
+	config        *configs.Config
+	cgroupManager cgroups.Manager
+	initProcess   parentProcess
+	state         containerState
+
+	m sync.Mutex
+}
+
+func (c *linuxContainer) ID() string {
+	return c.id
+}
+
+func (c *linuxContainer) Config() configs.Config {
+	return *c.config
+}
+
+func (c *linuxContainer) Status() (Status, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.currentStatus()
+}
+
+func (c *linuxContainer) currentStatus() (Status, error) {
+	if c.state == nil {
+		return Stopped, nil
+	}
+	return c.state.status(), nil
+}
+
+func (c *linuxContainer) State() (*State, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.currentState()
+}
+
+func (c *linuxContainer) currentState() (*State, error) {
+	var (
+		startTime           string
+		externalDescriptors []string
+		pid                 = -1
+	)
+	if c.initProcess != nil {
+		pid = c.initProcess.pid()
+		var err error
+		if startTime, err = c.initProcess.startTime(); err != nil {
+			return nil, newSystemErrorWithCause(err, "getting container init process start time")
+		}
+		externalDescriptors = c.initProcess.externalDescriptors()
+	}
+	allPids, err := c.cgroupManager.GetAllPids()
+	if err != nil {
+		return nil, newSystemErrorWithCause(err, "getting all container pids from cgroups")
+	}
+	state := &State{
+		BaseState: BaseState{
+			ID:                   c.ID(),
+			Config:               *c.config,
+			InitProcessPid:       pid,
+			InitProcessStartTime: startTime,
+		},
+		CgroupPaths:         c.cgroupManager.GetPaths(),
+		NamespacePaths:      make(map[configs.NamespaceType]string),
+		ExternalDescriptors: externalDescriptors,
+		AllPids:             allPids,
+	}
+	if pid > 0 {
+		for _, ns := range c.config.Namespaces {
+			if ns.Path != "" {
+				state.NamespacePaths[ns.Type] = ns.Path
+				continue
+			}
+			state.NamespacePaths[ns.Type] = fmt.Sprintf("/proc/%d/ns/%s", pid, ns.Type.File())
+		}
+	}
+	return state, nil
+}
+
+func (c *linuxContainer) Processes() ([]int, error) {
+	pids, err := c.cgroupManager.GetPids()
+	if err != nil {
+		return nil, newSystemErrorWithCause(err, "getting container pids from cgroups")
+	}
+	return pids, nil
+}
+
+// ProcessesRecursive returns the PIDs of every process in the container's
+// cgroup and all of its child cgroups, without requiring a separate call to
+// State() to observe the same data.
+func (c *linuxContainer) ProcessesRecursive() ([]int, error) {
+	pids, err := c.cgroupManager.GetAllPids()
+	if err != nil {
+		return nil, newSystemErrorWithCause(err, "getting all container pids from cgroups")
+	}
+	return pids, nil
+}
+
+// ProcessEventType identifies whether a ProcessEvent is reporting a pid that
+// joined or left the container's cgroups.
+type ProcessEventType int
+
+const (
+	// ProcessAdded is emitted for a pid observed for the first time.
+	ProcessAdded ProcessEventType = iota
+	// ProcessRemoved is emitted for a pid that was previously observed but
+	// is no longer present.
+	ProcessRemoved
+)
+
+// ProcessEvent describes a change in the container's process membership as
+// observed by WatchProcesses.
+type ProcessEvent struct {
+	Pid  int
+	Type ProcessEventType
+}
+
+// WatchProcesses polls the container's cgroups for membership changes and
+// emits a ProcessEvent on the returned channel for every pid that joins or
+// leaves, until ctx is cancelled, at which point the channel is closed. The
+// poll interval is configurable via the container's
+// configs.Config.ProcessWatchInterval.
+func (c *linuxContainer) WatchProcesses(ctx context.Context) <-chan ProcessEvent {
+	c.m.Lock()
+	interval := c.config.ProcessWatchInterval
+	c.m.Unlock()
+	if interval <= 0 {
+		interval = defaultProcessWatchInterval
+	}
+	events := make(chan ProcessEvent)
+	go func() {
+		defer close(events)
+		prev := make(map[int]struct{})
+		if pids, err := c.cgroupManager.GetAllPids(); err == nil {
+			for _, pid := range pids {
+				prev[pid] = struct{}{}
+			}
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			pids, err := c.cgroupManager.GetAllPids()
+			if err != nil {
+				continue
+			}
+			cur := make(map[int]struct{}, len(pids))
+			for _, pid := range pids {
+				cur[pid] = struct{}{}
+				if _, ok := prev[pid]; !ok {
+					if !sendProcessEvent(ctx, events, ProcessEvent{Pid: pid, Type: ProcessAdded}) {
+						return
+					}
+				}
+			}
+			for pid := range prev {
+				if _, ok := cur[pid]; !ok {
+					if !sendProcessEvent(ctx, events, ProcessEvent{Pid: pid, Type: ProcessRemoved}) {
+						return
+					}
+				}
+			}
+			prev = cur
+		}
+	}()
+	return events
+}
+
+func sendProcessEvent(ctx context.Context, events chan<- ProcessEvent, ev ProcessEvent) bool {
+	select {
+	case events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (c *linuxContainer) Stats() (*Stats, error) {
+	stats := &Stats{}
+	cgroupStats, err := c.cgroupManager.GetStats()
+	if err != nil {
+		return stats, newSystemErrorWithCause(err, "getting container stats from cgroups")
+	}
+	stats.CgroupStats = cgroupStats
+	return stats, nil
+}
+
+func (c *linuxContainer) Set(config configs.Config) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if err := c.cgroupManager.Set(&config); err != nil {
+		// Set failed, undo this change.
+		c.cgroupManager.Set(c.config)
+		return err
+	}
+	c.config = &config
+	return nil
+}
+
+// Update re-applies a subset of the container's cgroup resource limits,
+// leaving any field left zero-valued in resources untouched and the
+// container's freezer state as it was.
+func (c *linuxContainer) Update(resources *configs.Resources) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if err := c.cgroupManager.Update(resources); err != nil {
+		return newSystemErrorWithCause(err, "updating container resources")
+	}
+	if c.config.Resources == nil {
+		c.config.Resources = &configs.Resources{}
+	}
+	c.config.Resources.ApplyUpdate(resources)
+	return nil
+}
+
+// parseState parses the third field of a /proc/pid/stat line (the process
+// state character, e.g. 'R' or 'S') and returns it as an int, skipping over
+// the second field (the executable name in parens, which may itself contain
+// spaces or parens).
+func parseState(line string) (int, error) {
+	i := strings.LastIndex(line, ")")
+	if i < 0 || i+2 > len(line) {
+		return 0, fmt.Errorf("invalid /proc/pid/stat line: %q", line)
+	}
+	fields := strings.Fields(line[i+1:])
+	if len(fields) < 1 {
+		return 0, fmt.Errorf("invalid /proc/pid/stat line: %q", line)
+	}
+	state := fields[0]
+	if len(state) != 1 {
+		return 0, fmt.Errorf("invalid process state %q", state)
+	}
+	return int(state[0]), nil
+}
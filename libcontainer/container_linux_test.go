@@ -1,21 +1,27 @@
+//go:build linux
 // +build linux
 
 package libcontainer
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
 )
 
 type mockCgroupManager struct {
-	pids    []int
-	allPids []int
-	stats   *cgroups.Stats
-	paths   map[string]string
+	pids      []int
+	allPids   []int
+	stats     *cgroups.Stats
+	paths     map[string]string
+	resources *configs.Resources
+	freezer   configs.FreezerState
 }
 
 func (m *mockCgroupManager) GetPids() ([]int, error) {
@@ -47,6 +53,15 @@ func (m *mockCgroupManager) GetPaths() map[string]string {
 }
 
 func (m *mockCgroupManager) Freeze(state configs.FreezerState) error {
+	m.freezer = state
+	return nil
+}
+
+func (m *mockCgroupManager) Update(resources *configs.Resources) error {
+	if m.resources == nil {
+		m.resources = &configs.Resources{}
+	}
+	m.resources.ApplyUpdate(resources)
 	return nil
 }
 
@@ -90,7 +105,7 @@ func TestGetContainerPids(t *testing.T) {
 	container := &linuxContainer{
 		id:            "myid",
 		config:        &configs.Config{},
-		cgroupManager: &mockCgroupManager{allPids: []int{1, 2, 3}},
+		cgroupManager: &mockCgroupManager{pids: []int{1, 2, 3}},
 	}
 	pids, err := container.Processes()
 	if err != nil {
@@ -115,6 +130,11 @@ func TestGetContainerStats(t *testing.T) {
 						Usage: 1024,
 					},
 				},
+				PressureStats: cgroups.PressureStats{
+					CPU: cgroups.PSIStats{
+						Some: cgroups.PSIData{Avg10: 0.1, Avg60: 0.2, Avg300: 0.3, Total: 1000},
+					},
+				},
 			},
 		},
 	}
@@ -128,6 +148,12 @@ func TestGetContainerStats(t *testing.T) {
 	if stats.CgroupStats.MemoryStats.Usage.Usage != 1024 {
 		t.Fatalf("expected memory usage 1024 but recevied %d", stats.CgroupStats.MemoryStats.Usage.Usage)
 	}
+	if stats.CgroupStats.PressureStats.CPU.Some.Avg10 != 0.1 {
+		t.Fatalf("expected cpu psi avg10 0.1 but received %f", stats.CgroupStats.PressureStats.CPU.Some.Avg10)
+	}
+	if stats.CgroupStats.PressureStats.CPU.Some.Total != 1000 {
+		t.Fatalf("expected cpu psi total 1000 but received %d", stats.CgroupStats.PressureStats.CPU.Some.Total)
+	}
 }
 
 func TestGetContainerState(t *testing.T) {
@@ -153,7 +179,8 @@ func TestGetContainerState(t *testing.T) {
 			started: "010",
 		},
 		cgroupManager: &mockCgroupManager{
-			pids: []int{1, 2, 3},
+			pids:    []int{1, 2, 3},
+			allPids: []int{1, 2, 3, 4, 5},
 			stats: &cgroups.Stats{
 				MemoryStats: cgroups.MemoryStats{
 					Usage: cgroups.MemoryData{
@@ -177,6 +204,20 @@ func TestGetContainerState(t *testing.T) {
 	if state.InitProcessStartTime != "010" {
 		t.Fatalf("expected process start time 010 but received %s", state.InitProcessStartTime)
 	}
+	for i, expected := range []int{1, 2, 3, 4, 5} {
+		if state.AllPids[i] != expected {
+			t.Fatalf("expected all pid %d but received %d", expected, state.AllPids[i])
+		}
+	}
+	recursive, err := container.ProcessesRecursive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []int{1, 2, 3, 4, 5} {
+		if recursive[i] != expected {
+			t.Fatalf("expected recursive pid %d but received %d", expected, recursive[i])
+		}
+	}
 	paths := state.CgroupPaths
 	if paths == nil {
 		t.Fatal("cgroup paths should not be nil")
@@ -217,10 +258,73 @@ func TestGetContainerState(t *testing.T) {
 	}
 }
 
+func TestContainerUpdate(t *testing.T) {
+	mgr := &mockCgroupManager{
+		resources: &configs.Resources{
+			CpuShares: 100,
+			Memory:    1024,
+		},
+	}
+	container := &linuxContainer{
+		id:            "myid",
+		config:        &configs.Config{Resources: &configs.Resources{CpuShares: 100, Memory: 1024}},
+		cgroupManager: mgr,
+	}
+	if err := container.Update(&configs.Resources{CpuQuota: 5000}); err != nil {
+		t.Fatal(err)
+	}
+	if mgr.resources.CpuQuota != 5000 {
+		t.Fatalf("expected cpu quota 5000 but received %d", mgr.resources.CpuQuota)
+	}
+	if mgr.resources.CpuShares != 100 {
+		t.Fatalf("expected untouched cpu shares 100 but received %d", mgr.resources.CpuShares)
+	}
+	if mgr.resources.Memory != 1024 {
+		t.Fatalf("expected untouched memory 1024 but received %d", mgr.resources.Memory)
+	}
+	if container.config.Resources.CpuQuota != 5000 {
+		t.Fatalf("expected container config cpu quota 5000 but received %d", container.config.Resources.CpuQuota)
+	}
+	if mgr.freezer != configs.Undefined {
+		t.Fatalf("expected freezer state to be left untouched, got %q", mgr.freezer)
+	}
+}
+
+// TestWatchProcessesConcurrentWithSet exercises WatchProcesses and Set
+// concurrently under the race detector: WatchProcesses reads
+// c.config.ProcessWatchInterval while Set may be replacing c.config, and
+// both must go through c.m.
+func TestWatchProcessesConcurrentWithSet(t *testing.T) {
+	container := &linuxContainer{
+		id:            "myid",
+		config:        &configs.Config{ProcessWatchInterval: time.Millisecond},
+		cgroupManager: &mockCgroupManager{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		events := container.WatchProcesses(ctx)
+		for range events {
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			container.Set(configs.Config{ProcessWatchInterval: time.Millisecond})
+		}
+	}()
+	wg.Wait()
+}
+
 func TestParseState(t *testing.T) {
 	data := map[string]int{
 		"4902 (gunicorn: maste) S 4885 4902 4902 0 -1 4194560 29683 29929 61 83 78 16 96 17 20 0 1 0 9126532 52965376 1903 18446744073709551615 4194304 7461796 140733928751520 140733928698072 139816984959091 0 0 16781312 137447943 1 0 0 17 3 0 0 9 0 0 9559488 10071156 33050624 140733928758775 140733928758945 140733928758945 140733928759264 0": 'S',
-		"9534 (cat) R 9323 9534 9323 34828 9534 4194304 95 0 0 0 0 0 0 0 20 0 1 0 9214966 7626752 168 18446744073709551615 4194304 4240332 140732237651568 140732237650920 140570710391216 0 0 0 0 0 0 0 17 1 0 0 0 0 0 6340112 6341364 21553152 140732237653865 140732237653885 140732237653885 140732237656047 0": 'R',
+		"9534 (cat) R 9323 9534 9323 34828 9534 4194304 95 0 0 0 0 0 0 0 20 0 1 0 9214966 7626752 168 18446744073709551615 4194304 4240332 140732237651568 140732237650920 140570710391216 0 0 0 0 0 0 0 17 1 0 0 0 0 0 6340112 6341364 21553152 140732237653865 140732237653885 140732237653885 140732237656047 0":                                      'R',
 
 		"24767 (irq/44-mei_me) S 2 0 0 0 -1 2129984 0 0 0 0 0 0 0 0 -51 0 1 0 8722075 0 0 18446744073709551615 0 0 0 0 0 0 0 2147483647 0 0 0 0 17 1 50 1 0 0 0 0 0 0 0 0 0 0 0": 'S',
 	}
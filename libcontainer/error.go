@@ -0,0 +1,26 @@
+package libcontainer
+
+import "fmt"
+
+// systemError wraps an error returned by the OS or another package so it can
+// be distinguished, when necessary, from errors originating in libcontainer
+// itself.
+type systemError struct {
+	cause error
+}
+
+func newSystemError(err error) error {
+	return &systemError{cause: err}
+}
+
+func newSystemErrorWithCause(err error, cause string) error {
+	return &systemError{cause: fmt.Errorf("%s: %w", cause, err)}
+}
+
+func (e *systemError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *systemError) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,120 @@
+// Package metrics periodically polls the cgroup stats of registered
+// containers and publishes them through pluggable Collectors, such as the
+// Prometheus collector in this package.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer"
+)
+
+// defaultScrapeInterval is how often registered containers are polled when
+// SetScrapeInterval has not been called.
+const defaultScrapeInterval = 15 * time.Second
+
+// Collector receives the stats collected for a container on every scrape,
+// and is told when a container is no longer tracked.
+type Collector interface {
+	// Observe is called with the latest stats for id on every scrape. A
+	// container whose stats cannot be read is not observed; it is
+	// unregistered, and Remove is called for it instead.
+	Observe(id string, stats *libcontainer.Stats)
+
+	// Remove is called when id is no longer tracked, so the collector can
+	// drop any series or labels it was keeping for it.
+	Remove(id string)
+}
+
+var (
+	mu         sync.Mutex
+	containers = make(map[string]libcontainer.Container)
+	collectors []Collector
+	interval   = defaultScrapeInterval
+	stop       chan struct{}
+)
+
+// AddCollector registers c to receive stats on every scrape of every
+// container registered with Register.
+func AddCollector(c Collector) {
+	mu.Lock()
+	defer mu.Unlock()
+	collectors = append(collectors, c)
+}
+
+// SetScrapeInterval overrides the default scrape interval. It only takes
+// effect for scrapes that have not yet been scheduled, so call it before
+// the first Register.
+func SetScrapeInterval(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	interval = d
+}
+
+// Register starts tracking container, polling its stats on every scrape
+// until Unregister is called with its ID. The first call to Register in a
+// process starts the single background scrape goroutine; subsequent calls
+// just add to the tracked set.
+func Register(container libcontainer.Container) {
+	mu.Lock()
+	defer mu.Unlock()
+	containers[container.ID()] = container
+	if stop == nil {
+		stop = make(chan struct{})
+		go run(stop)
+	}
+}
+
+// Unregister stops tracking the container with the given id and notifies
+// every collector to drop it.
+func Unregister(id string) {
+	mu.Lock()
+	delete(containers, id)
+	cs := append([]Collector(nil), collectors...)
+	mu.Unlock()
+
+	for _, c := range cs {
+		c.Remove(id)
+	}
+}
+
+func run(stop <-chan struct{}) {
+	for {
+		mu.Lock()
+		d := interval
+		mu.Unlock()
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(d):
+			scrape()
+		}
+	}
+}
+
+func scrape() {
+	mu.Lock()
+	ids := make([]string, 0, len(containers))
+	tracked := make(map[string]libcontainer.Container, len(containers))
+	for id, c := range containers {
+		ids = append(ids, id)
+		tracked[id] = c
+	}
+	cs := append([]Collector(nil), collectors...)
+	mu.Unlock()
+
+	for _, id := range ids {
+		stats, err := tracked[id].Stats()
+		if err != nil {
+			// The container's cgroup path is most likely gone; stop
+			// polling it rather than erroring on every future scrape.
+			Unregister(id)
+			continue
+		}
+		for _, c := range cs {
+			c.Observe(id, stats)
+		}
+	}
+}
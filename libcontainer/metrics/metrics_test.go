@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// mockContainer is a minimal libcontainer.Container used to drive the
+// scrape loop with synthetic stats, without needing a real cgroup manager.
+type mockContainer struct {
+	id    string
+	stats *cgroups.Stats
+	err   error
+}
+
+func (m *mockContainer) ID() string             { return m.id }
+func (m *mockContainer) Config() configs.Config { return configs.Config{} }
+func (m *mockContainer) Status() (libcontainer.Status, error) {
+	return libcontainer.Running, nil
+}
+func (m *mockContainer) State() (*libcontainer.State, error) { return nil, nil }
+func (m *mockContainer) Processes() ([]int, error)           { return nil, nil }
+func (m *mockContainer) ProcessesRecursive() ([]int, error)  { return nil, nil }
+func (m *mockContainer) WatchProcesses(ctx context.Context) <-chan libcontainer.ProcessEvent {
+	return nil
+}
+func (m *mockContainer) Stats() (*libcontainer.Stats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &libcontainer.Stats{CgroupStats: m.stats}, nil
+}
+func (m *mockContainer) Set(config configs.Config) error           { return nil }
+func (m *mockContainer) Update(resources *configs.Resources) error { return nil }
+
+// recordingCollector tracks every id it was asked to Observe or Remove, so
+// tests can assert on scrape and teardown behavior without a real registry.
+// Its maps are guarded by mu since Observe/Remove are called from the
+// background scrape goroutine while tests read them from the test goroutine.
+type recordingCollector struct {
+	mu       sync.Mutex
+	observed map[string]*libcontainer.Stats
+	removed  map[string]bool
+	// notify is signalled (non-blocking) after every Observe, so tests can
+	// wait for a scrape instead of polling on a timer.
+	notify chan struct{}
+}
+
+func newRecordingCollector() *recordingCollector {
+	return &recordingCollector{
+		observed: make(map[string]*libcontainer.Stats),
+		removed:  make(map[string]bool),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+func (r *recordingCollector) Observe(id string, stats *libcontainer.Stats) {
+	r.mu.Lock()
+	r.observed[id] = stats
+	r.mu.Unlock()
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (r *recordingCollector) Remove(id string) {
+	r.mu.Lock()
+	r.removed[id] = true
+	r.mu.Unlock()
+}
+
+func (r *recordingCollector) get(id string) (*libcontainer.Stats, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats, ok := r.observed[id]
+	return stats, ok
+}
+
+func (r *recordingCollector) isRemoved(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.removed[id]
+}
+
+func resetGlobalState() {
+	mu.Lock()
+	defer mu.Unlock()
+	containers = make(map[string]libcontainer.Container)
+	collectors = nil
+	interval = defaultScrapeInterval
+	stop = nil
+}
+
+func TestScrapeObservesRegisteredContainers(t *testing.T) {
+	resetGlobalState()
+	SetScrapeInterval(10 * time.Millisecond)
+	rec := newRecordingCollector()
+	AddCollector(rec)
+
+	c := &mockContainer{
+		id: "myid",
+		stats: &cgroups.Stats{
+			MemoryStats: cgroups.MemoryStats{Usage: cgroups.MemoryData{Usage: 2048}},
+		},
+	}
+	Register(c)
+
+	select {
+	case <-rec.notify:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a scrape to observe myid")
+	}
+
+	stats, ok := rec.get("myid")
+	if !ok {
+		t.Fatal("expected container myid to have been observed")
+	}
+	if stats.CgroupStats.MemoryStats.Usage.Usage != 2048 {
+		t.Fatalf("expected memory usage 2048 but received %d", stats.CgroupStats.MemoryStats.Usage.Usage)
+	}
+
+	Unregister("myid")
+	if !rec.isRemoved("myid") {
+		t.Fatal("expected container myid to have been removed from the collector")
+	}
+}
+
+// TestPrometheusCollectorTracksContainers exercises the real
+// PrometheusCollector, not just the test-only recordingCollector: a
+// registered container's stats must show up as Prometheus samples, and an
+// unregistered container's samples must disappear.
+func TestPrometheusCollectorTracksContainers(t *testing.T) {
+	resetGlobalState()
+	SetScrapeInterval(10 * time.Millisecond)
+	pc := NewPrometheusCollector()
+	AddCollector(pc)
+
+	c := &mockContainer{
+		id: "myid",
+		stats: &cgroups.Stats{
+			MemoryStats: cgroups.MemoryStats{Usage: cgroups.MemoryData{Usage: 4096}},
+		},
+	}
+	Register(c)
+
+	deadline := time.Now().Add(time.Second)
+	for testutil.ToFloat64(pc.memoryUsage.WithLabelValues("myid")) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := testutil.ToFloat64(pc.memoryUsage.WithLabelValues("myid")); got != 4096 {
+		t.Fatalf("expected a memory_usage_bytes sample of 4096 for myid, got %v", got)
+	}
+
+	Unregister("myid")
+	if got := testutil.CollectAndCount(pc.memoryUsage); got != 0 {
+		t.Fatalf("expected the memory_usage_bytes sample for myid to be gone after Unregister, got %d samples", got)
+	}
+}
+
+// TestScrapeUnregistersOnStatsError covers the case where a container's
+// cgroup path has been destroyed out from under it: Stats() starts failing,
+// and scrape must stop polling it rather than erroring on every future tick.
+func TestScrapeUnregistersOnStatsError(t *testing.T) {
+	resetGlobalState()
+	SetScrapeInterval(10 * time.Millisecond)
+	rec := newRecordingCollector()
+	AddCollector(rec)
+
+	c := &mockContainer{id: "gone", err: errors.New("cgroup path destroyed")}
+	Register(c)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		_, tracked := containers["gone"]
+		mu.Unlock()
+		if !tracked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for scrape to drop a container whose Stats() errors")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if !rec.isRemoved("gone") {
+		t.Fatal("expected container gone to have been removed from the collector after a Stats error")
+	}
+}
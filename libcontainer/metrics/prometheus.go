@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"github.com/opencontainers/runc/libcontainer"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusCollector is a Collector that exposes cpu, memory, blkio and
+// pids gauges for every tracked container, labeled by container id.
+type PrometheusCollector struct {
+	cpuUsage    *prometheus.GaugeVec
+	memoryUsage *prometheus.GaugeVec
+	blkioBytes  *prometheus.GaugeVec
+	pidsCurrent *prometheus.GaugeVec
+}
+
+// NewPrometheusCollector creates a PrometheusCollector. The returned
+// collector must be registered with a prometheus.Registerer (e.g. via
+// prometheus.MustRegister) by the caller in order to be scraped.
+func NewPrometheusCollector() *PrometheusCollector {
+	labels := []string{"id"}
+	return &PrometheusCollector{
+		cpuUsage: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "runc",
+			Subsystem: "container",
+			Name:      "cpu_usage_seconds_total",
+			Help:      "Cumulative CPU time consumed by the container, in seconds.",
+		}, labels),
+		memoryUsage: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "runc",
+			Subsystem: "container",
+			Name:      "memory_usage_bytes",
+			Help:      "Current memory usage of the container, in bytes.",
+		}, labels),
+		blkioBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "runc",
+			Subsystem: "container",
+			Name:      "blkio_io_service_bytes_total",
+			Help:      "Cumulative bytes transferred to/from block devices by the container.",
+		}, labels),
+		pidsCurrent: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "runc",
+			Subsystem: "container",
+			Name:      "pids_current",
+			Help:      "Current number of pids in the container's cgroup.",
+		}, labels),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (p *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	p.cpuUsage.Describe(ch)
+	p.memoryUsage.Describe(ch)
+	p.blkioBytes.Describe(ch)
+	p.pidsCurrent.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (p *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	p.cpuUsage.Collect(ch)
+	p.memoryUsage.Collect(ch)
+	p.blkioBytes.Collect(ch)
+	p.pidsCurrent.Collect(ch)
+}
+
+// Observe implements Collector.
+func (p *PrometheusCollector) Observe(id string, stats *libcontainer.Stats) {
+	if stats == nil || stats.CgroupStats == nil {
+		return
+	}
+	cg := stats.CgroupStats
+	p.cpuUsage.WithLabelValues(id).Set(float64(cg.CpuStats.CpuUsage.TotalUsage) / 1e9)
+	p.memoryUsage.WithLabelValues(id).Set(float64(cg.MemoryStats.Usage.Usage))
+
+	var blkio uint64
+	for _, entry := range cg.BlkioStats.IoServiceBytesRecursive {
+		blkio += entry.Value
+	}
+	p.blkioBytes.WithLabelValues(id).Set(float64(blkio))
+
+	p.pidsCurrent.WithLabelValues(id).Set(float64(cg.PidsStats.Current))
+}
+
+// Remove implements Collector.
+func (p *PrometheusCollector) Remove(id string) {
+	p.cpuUsage.DeleteLabelValues(id)
+	p.memoryUsage.DeleteLabelValues(id)
+	p.blkioBytes.DeleteLabelValues(id)
+	p.pidsCurrent.DeleteLabelValues(id)
+}
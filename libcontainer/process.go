@@ -0,0 +1,36 @@
+package libcontainer
+
+import "os"
+
+// parentProcess is the interface through which a linuxContainer drives the
+// lifecycle of its init process, whether that process is a real child of
+// this process or one being restored from a checkpoint.
+type parentProcess interface {
+	// pid returns the pid for the running process.
+	pid() int
+
+	// start starts the process execution.
+	start() error
+
+	// terminate sends a SIGKILL to the process and waits for it to exit.
+	terminate() error
+
+	// wait waits on the process and returns its exit status once it has
+	// exited.
+	wait() (*os.ProcessState, error)
+
+	// startTime returns the process start time, read from /proc/pid/stat,
+	// used to detect pid reuse.
+	startTime() (string, error)
+
+	// signal sends the given signal to the process.
+	signal(os.Signal) error
+
+	// externalDescriptors returns extra file descriptors inherited by the
+	// process that need to be tracked across a checkpoint/restore.
+	externalDescriptors() []string
+
+	// setExternalDescriptors sets the extra file descriptors to be tracked
+	// across a checkpoint/restore.
+	setExternalDescriptors(newFds []string)
+}
@@ -0,0 +1,119 @@
+//go:build linux
+// +build linux
+
+package libcontainer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/sigbomb"
+)
+
+// TestMockProcessSurvivesSignalStorm bombs the current process (the one
+// mockProcess reports as its pid) with SIGURG via sigbomb.Bomber, to make
+// sure a container's bookkeeping around its init process doesn't assume
+// signal delivery is rare.
+func TestMockProcessSurvivesSignalStorm(t *testing.T) {
+	received := make(chan os.Signal, 256)
+	signal.Notify(received, syscall.SIGURG)
+	defer signal.Stop(received)
+
+	proc := &mockProcess{_pid: os.Getpid(), started: "010"}
+	container := &linuxContainer{
+		id:            "myid",
+		config:        &configs.Config{},
+		initProcess:   proc,
+		cgroupManager: &mockCgroupManager{},
+	}
+	container.state = &runningState{c: container}
+
+	b := sigbomb.New(syscall.SIGURG, 2000).Target(proc.pid())
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	<-ctx.Done()
+	b.Stop()
+
+	if len(received) == 0 {
+		t.Fatal("expected at least one signal to have been delivered during the storm")
+	}
+	for len(received) > 0 {
+		<-received
+	}
+
+	if _, err := container.State(); err != nil {
+		t.Fatalf("container state should still be readable after a signal storm: %v", err)
+	}
+}
+
+// execProcess adapts an *exec.Cmd to the parentProcess interface, standing
+// in for a real container init process.
+type execProcess struct {
+	cmd *exec.Cmd
+}
+
+func (e *execProcess) pid() int { return e.cmd.Process.Pid }
+func (e *execProcess) start() error {
+	return e.cmd.Start()
+}
+
+// terminate only sends SIGKILL; it does not wait, so the test observes the
+// child's exit through wait().
+func (e *execProcess) terminate() error {
+	return e.cmd.Process.Kill()
+}
+func (e *execProcess) wait() (*os.ProcessState, error) {
+	err := e.cmd.Wait()
+	return e.cmd.ProcessState, err
+}
+func (e *execProcess) startTime() (string, error)    { return "0", nil }
+func (e *execProcess) signal(sig os.Signal) error    { return e.cmd.Process.Signal(sig) }
+func (e *execProcess) externalDescriptors() []string { return nil }
+func (e *execProcess) setExternalDescriptors([]string) {
+}
+
+// TestRealInitProcessSurvivesSignalStorm bombs a real child process with
+// SIGURG (the signal Go's own runtime uses for async preemption, and so is
+// ignored by default), then kills it and checks that wait() still reports
+// its exit, i.e. the storm didn't drop the child's exit notification.
+func TestRealInitProcessSurvivesSignalStorm(t *testing.T) {
+	proc := &execProcess{cmd: exec.Command("sleep", "1")}
+	if err := proc.start(); err != nil {
+		t.Skipf("could not start test child process: %v", err)
+	}
+
+	container := &linuxContainer{
+		id:            "myid",
+		config:        &configs.Config{},
+		initProcess:   proc,
+		cgroupManager: &mockCgroupManager{},
+	}
+	container.state = &runningState{c: container}
+
+	b := sigbomb.New(syscall.SIGURG, 500).Target(proc.pid())
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	<-ctx.Done()
+	b.Stop()
+
+	if err := container.initProcess.terminate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := container.initProcess.wait(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatalf("expected wait() to report the child's exit, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package libcontainer
+
+// containerState represents a running container's current state, and
+// governs the legal transitions between states.
+type containerState interface {
+	transition(containerState) error
+	destroy() error
+	status() Status
+}
+
+// createdState represents a container that has been created but whose init
+// process has not yet started running user code.
+type createdState struct {
+	c *linuxContainer
+}
+
+func (s *createdState) status() Status {
+	return Created
+}
+
+func (s *createdState) transition(t containerState) error {
+	s.c.state = t
+	return nil
+}
+
+func (s *createdState) destroy() error {
+	return destroy(s.c)
+}
+
+// runningState represents a container whose init process is running.
+type runningState struct {
+	c *linuxContainer
+}
+
+func (s *runningState) status() Status {
+	return Running
+}
+
+func (s *runningState) transition(t containerState) error {
+	s.c.state = t
+	return nil
+}
+
+func (s *runningState) destroy() error {
+	return destroy(s.c)
+}
+
+// stoppedState represents a container with no running processes.
+type stoppedState struct {
+	c *linuxContainer
+}
+
+func (s *stoppedState) status() Status {
+	return Stopped
+}
+
+func (s *stoppedState) transition(t containerState) error {
+	s.c.state = t
+	return nil
+}
+
+func (s *stoppedState) destroy() error {
+	return destroy(s.c)
+}
+
+func destroy(c *linuxContainer) error {
+	if err := c.cgroupManager.Destroy(); err != nil {
+		return newSystemError(err)
+	}
+	return nil
+}
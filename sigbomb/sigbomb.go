@@ -1,18 +1,93 @@
+// Package sigbomb drives a process's signal handling under bounded,
+// rate-limited load, for testing how well a program copes with a storm of
+// a given signal. Nothing runs until a Bomber is explicitly started.
 package sigbomb
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"syscall"
+
+	"golang.org/x/time/rate"
 )
 
-func Start() {
-	p, err := os.FindProcess(os.Getpid())
+// Bomber repeatedly sends a signal to a target process at a bounded rate.
+type Bomber struct {
+	sig     syscall.Signal
+	limiter *rate.Limiter
+	pid     int
+
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// New creates a Bomber that sends sig to itself at up to ratePerSecond
+// signals per second, once started. Use Target to point it at another
+// process instead.
+func New(sig syscall.Signal, ratePerSecond int) *Bomber {
+	return &Bomber{
+		sig:     sig,
+		limiter: newLimiter(ratePerSecond),
+		pid:     os.Getpid(),
+	}
+}
+
+func newLimiter(signalsPerSecond int) *rate.Limiter {
+	if signalsPerSecond <= 0 {
+		signalsPerSecond = 1
+	}
+	return rate.NewLimiter(rate.Limit(signalsPerSecond), signalsPerSecond)
+}
+
+// Target points the Bomber at pid instead of the current process. It must
+// be called before Start.
+func (b *Bomber) Target(pid int) *Bomber {
+	b.pid = pid
+	return b
+}
+
+// Start begins sending signals in a background goroutine, until ctx is
+// cancelled or Stop is called. Start must not be called again until a
+// prior run has been stopped.
+func (b *Bomber) Start(ctx context.Context) error {
+	if b.cancel != nil {
+		return fmt.Errorf("sigbomb: Bomber already started")
+	}
+
+	proc, err := os.FindProcess(b.pid)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("sigbomb: finding target process %d: %w", b.pid, err)
 	}
-	go func() {
-		for {
-			p.Signal(syscall.SIGURG)
+
+	ctx, cancel := context.WithCancel(ctx)
+	b.cancel = cancel
+	b.stopped = make(chan struct{})
+	go b.run(ctx, proc)
+	return nil
+}
+
+// Stop cancels the Bomber and waits for its goroutine to exit. It is a
+// no-op if Start was never called. After Stop returns, the Bomber may be
+// Start'ed again.
+func (b *Bomber) Stop() {
+	if b.cancel == nil {
+		return
+	}
+	b.cancel()
+	<-b.stopped
+	b.cancel = nil
+}
+
+func (b *Bomber) run(ctx context.Context, proc *os.Process) {
+	defer close(b.stopped)
+	for {
+		if err := b.limiter.Wait(ctx); err != nil {
+			// ctx was cancelled.
+			return
 		}
-	}()
+		if err := proc.Signal(b.sig); err != nil {
+			return
+		}
+	}
 }
@@ -0,0 +1,42 @@
+//go:build linux
+// +build linux
+
+package sigbomb
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestBomberStopsOnContextCancel(t *testing.T) {
+	received := make(chan os.Signal, 64)
+	signal.Notify(received, syscall.SIGURG)
+	defer signal.Stop(received)
+
+	b := New(syscall.SIGURG, 200)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	<-ctx.Done()
+	b.Stop()
+
+	if len(received) == 0 {
+		t.Fatal("expected at least one signal to have been delivered")
+	}
+
+	// Drain, then confirm no more signals arrive once stopped.
+	for len(received) > 0 {
+		<-received
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(received) != 0 {
+		t.Fatalf("expected no signals after Stop, got %d", len(received))
+	}
+}